@@ -6,9 +6,13 @@ package dashboard
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 	"time"
+
+	"golang.org/x/build/buildgo"
+	"golang.org/x/build/types"
 )
 
 func TestOSARCHAccessors(t *testing.T) {
@@ -497,6 +501,56 @@ func TestBuilderConfig(t *testing.T) {
 		{b("freebsd-386-11_2@go1.11", "net"), none},
 		{b("freebsd-386-12_0@go1.11", "net"), none},
 	}
+
+	// Generated from osSunsets, for every host type it covers that's
+	// actually registered: HostSupportsGoBranch's sunset window
+	// should agree with BuildsRepoPostSubmit for the "go" repo at the
+	// branch immediately before, at, and after each bound. Trybot
+	// status isn't part of the sunset window, so inside the window
+	// only post-submit is asserted.
+	//
+	// This is additive, not a replacement for the hand-written
+	// FreeBSD 10.x/11.1 cases (search for "go1.12.html") and
+	// darwin-amd64-10_10 cases above: confirming those are now
+	// redundant, rather than silently dropping an edge case osSunsets
+	// doesn't model (like the two-step go1.11/go1.12 FreeBSD 11.1
+	// entries), means running this against BuildsRepoPostSubmit's
+	// real implementation, and builders.go isn't part of this
+	// checkout.
+	addWant := func(br builderAndRepo, w want) {
+		tests = append(tests, struct {
+			br   builderAndRepo
+			want want
+		}{br, w})
+	}
+	hostTypes := make([]string, 0, len(osSunsets))
+	for hostType := range osSunsets {
+		hostTypes = append(hostTypes, hostType)
+	}
+	sort.Strings(hostTypes)
+	zero := types.MajorMinor{}
+	for _, hostType := range hostTypes {
+		rel := osSunsets[hostType]
+		var builder string
+		for _, conf := range Builders {
+			if conf.HostType == hostType {
+				builder = conf.Name
+				break
+			}
+		}
+		if builder == "" {
+			continue
+		}
+		if rel.FirstGoVersion != zero {
+			addWant(b(fmt.Sprintf("%s@go%d.%d", builder, rel.FirstGoVersion.Major, rel.FirstGoVersion.Minor-1), "go"), none)
+			addWant(b(fmt.Sprintf("%s@go%d.%d", builder, rel.FirstGoVersion.Major, rel.FirstGoVersion.Minor), "go"), isBuilder)
+		}
+		if rel.LastGoVersion != zero {
+			addWant(b(fmt.Sprintf("%s@go%d.%d", builder, rel.LastGoVersion.Major, rel.LastGoVersion.Minor), "go"), isBuilder)
+			addWant(b(fmt.Sprintf("%s@go%d.%d", builder, rel.LastGoVersion.Major, rel.LastGoVersion.Minor+1), "go"), none)
+		}
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.br.testName, func(t *testing.T) {
 			bc, ok := Builders[tt.br.builder]
@@ -526,6 +580,264 @@ func TestBuilderConfig(t *testing.T) {
 	}
 }
 
+func TestHostSupportsGoBranch(t *testing.T) {
+	tests := []struct {
+		hostType string
+		goBranch string
+		want     bool
+	}{
+		{"host-freebsd-10_3", "release-branch.go1.12", true},
+		{"host-freebsd-10_3", "release-branch.go1.13", false},
+		{"host-freebsd-11_2", "release-branch.go1.16", true},
+		{"host-freebsd-11_2", "release-branch.go1.17", false},
+		{"host-windows-2008", "master", true},
+		{"host-windows-2008", "release-branch.go1.9", true},
+		{"host-not-in-registry", "master", true},
+	}
+	for _, tt := range tests {
+		if got := HostSupportsGoBranch(tt.hostType, tt.goBranch); got != tt.want {
+			t.Errorf("HostSupportsGoBranch(%q, %q) = %v; want %v", tt.hostType, tt.goBranch, got, tt.want)
+		}
+	}
+
+	// Every host type declared in osSunsets should agree with
+	// BuildsRepoPostSubmit for the "go" repo, for any builder that
+	// actually uses it.
+	for _, conf := range Builders {
+		rel, ok := osSunsets[conf.HostType]
+		if !ok {
+			continue
+		}
+		if rel.LastGoVersion == (types.MajorMinor{}) {
+			continue
+		}
+		sunsetBranch := fmt.Sprintf("release-branch.go1.%d", rel.LastGoVersion.Minor+1)
+		if conf.BuildsRepoPostSubmit("go", sunsetBranch, sunsetBranch) {
+			t.Errorf("builder %q still builds %q after its declared sunset at go1.%d", conf.Name, sunsetBranch, rel.LastGoVersion.Minor)
+		}
+	}
+}
+
+func TestIOSGOOS(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantGOOS string
+		wantOK   bool
+	}{
+		{"ios-arm64-corellium", "ios", true},
+		{"darwin-arm64-corellium", "", false},
+		{"android-arm64-corellium", "", false},
+	}
+	for _, tt := range tests {
+		goos, ok := iosGOOS(tt.name)
+		if goos != tt.wantGOOS || ok != tt.wantOK {
+			t.Errorf("iosGOOS(%q) = %q, %v; want %q, %v", tt.name, goos, ok, tt.wantGOOS, tt.wantOK)
+		}
+	}
+}
+
+func TestSchedulingCostMonotonic(t *testing.T) {
+	c := &BuildConfig{SchedulerPolicy: SchedulerPolicy{Priority: 5}}
+	now := time.Now()
+	// Both revs are the zero value; costing one an hour later than the
+	// other makes it the older (longer-queued) entry of the two.
+	older := buildgo.BuilderRev{}
+	newer := buildgo.BuilderRev{}
+	costOlder := c.SchedulingCost(older, now.Add(time.Hour))
+	costNewer := c.SchedulingCost(newer, now)
+	if costOlder > costNewer {
+		t.Errorf("by default, an older entry's cost (%v) should not exceed a newer one's (%v)", costOlder, costNewer)
+	}
+
+	c.SchedulerPolicy.PreferNewer = true
+	costOlderPN := c.SchedulingCost(older, now.Add(time.Hour))
+	costNewerPN := c.SchedulingCost(newer, now)
+	if costNewerPN > costOlderPN {
+		t.Errorf("with PreferNewer, a newer entry should sort before (cost <=) an older one")
+	}
+}
+
+func TestExpensiveBuildersCapMaxAtOnce(t *testing.T) {
+	expensivePrefixes := []string{"android-", "linux-amd64-longtest"}
+	for name, conf := range Builders {
+		for _, p := range expensivePrefixes {
+			if strings.HasPrefix(name, p) && conf.SchedulerPolicy.MaxAtOnce == 0 {
+				t.Errorf("builder %q looks expensive but has no MaxAtOnce cap", name)
+			}
+		}
+	}
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRegisterVariant(t *testing.T) {
+	const base = "test-variant-base"
+	Builders[base] = &BuildConfig{
+		Name:     base,
+		HostType: "host-linux-stretch",
+		env:      []string{"GOOS=linux"},
+		Repos:    []string{"go"},
+	}
+	defer delete(Builders, base)
+
+	nc := RegisterVariant(base, BuildVariant{
+		Name:             "softfloat",
+		Env:              []string{"GO386=softfloat"},
+		MinimumGoVersion: types.MajorMinor{Major: 1, Minor: 16},
+		GoDeps:           []string{"260017deadbeef"},
+		Repos:            []string{"go", "crypto"},
+	})
+	defer delete(Builders, base+"-softfloat")
+
+	if nc.Name != base+"-softfloat" {
+		t.Errorf("Name = %q; want %q", nc.Name, base+"-softfloat")
+	}
+	if nc.HostType != "host-linux-stretch" {
+		t.Errorf("variant disagrees with its base on HostType: got %q", nc.HostType)
+	}
+	if got := Builders[base].env; len(got) != 1 {
+		t.Errorf("RegisterVariant mutated the base builder's env: %v", got)
+	}
+	foundGO386 := false
+	for _, e := range nc.env {
+		if e == "GO386=softfloat" {
+			foundGO386 = true
+		}
+	}
+	if !foundGO386 {
+		t.Errorf("variant env %v missing GO386=softfloat", nc.env)
+	}
+	if want := (types.MajorMinor{Major: 1, Minor: 16}); nc.MinimumGoVersion != want {
+		t.Errorf("MinimumGoVersion = %+v; want %+v", nc.MinimumGoVersion, want)
+	}
+	if want := []string{"go", "crypto"}; !equalStringSlices(nc.Repos, want) {
+		t.Errorf("Repos = %v; want %v", nc.Repos, want)
+	}
+
+	// A variant that doesn't set Repos builds the same repos as its base.
+	nc2 := RegisterVariant(base, BuildVariant{Name: "noop"})
+	defer delete(Builders, base+"-noop")
+	if want := []string{"go"}; !equalStringSlices(nc2.Repos, want) {
+		t.Errorf("Repos with no variant override = %v; want %v (inherited from base)", nc2.Repos, want)
+	}
+}
+
+func TestAtLeastGo1(t *testing.T) {
+	tests := []struct {
+		goBranch string
+		n        int
+		want     bool
+	}{
+		{"master", 99, true},
+		{"release-branch.go1.12", 12, true},
+		{"release-branch.go1.12", 11, true},
+		{"release-branch.go1.12", 13, false},
+		{"release-branch.go1.16", 16, true},
+		{"dev.fuzz", 1, false}, // not a release branch we understand
+	}
+	for _, tt := range tests {
+		if got := atLeastGo1(tt.goBranch, tt.n); got != tt.want {
+			t.Errorf("atLeastGo1(%q, %d) = %v; want %v", tt.goBranch, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestAtLeastGoVersion(t *testing.T) {
+	tests := []struct {
+		goBranch string
+		min      types.MajorMinor
+		want     bool
+	}{
+		{"master", types.MajorMinor{Major: 1, Minor: 99}, true},
+		{"release-branch.go1.16", types.MajorMinor{Major: 1, Minor: 16}, true},
+		{"release-branch.go1.16", types.MajorMinor{Major: 1, Minor: 17}, false},
+		{"dev.fuzz", types.MajorMinor{Major: 1, Minor: 1}, false},
+	}
+	for _, tt := range tests {
+		if got := atLeastGoVersion(tt.goBranch, tt.min); got != tt.want {
+			t.Errorf("atLeastGoVersion(%q, %+v) = %v; want %v", tt.goBranch, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestGoCommitContainsDeps(t *testing.T) {
+	ancestors := map[string][]string{
+		"child":  {"parent"},
+		"parent": {"root"},
+	}
+	var hasAncestor func(commit, ancestor string) bool
+	hasAncestor = func(commit, ancestor string) bool {
+		for _, a := range ancestors[commit] {
+			if a == ancestor || hasAncestor(a, ancestor) {
+				return true
+			}
+		}
+		return false
+	}
+	if !goCommitContainsDeps("child", []string{"root"}, hasAncestor) {
+		t.Error("expected child to contain root as a dependency")
+	}
+	if !goCommitContainsDeps("child", []string{"child"}, hasAncestor) {
+		t.Error("a commit should trivially satisfy itself as a dep")
+	}
+	if goCommitContainsDeps("parent", []string{"child"}, hasAncestor) {
+		t.Error("parent should not contain child, which comes after it")
+	}
+}
+
+// TestSlowBotAliases verifies that SlowBotAliases is internally
+// consistent: every non-empty alias must resolve to a real entry in
+// Builders, and every GOOS/GOARCH pair present in Builders should have
+// a reasonable alias a user could type in a "TRY=" comment. Aliases
+// explicitly mapped to "" are treated as documented gaps, not errors.
+func TestSlowBotAliases(t *testing.T) {
+	for alias, name := range SlowBotAliases {
+		if name == "" {
+			continue // documented as known-missing
+		}
+		if _, ok := Builders[name]; !ok {
+			t.Errorf("SlowBotAliases[%q] = %q, which is not in Builders", alias, name)
+		}
+	}
+
+	osArchSeen := map[string]bool{}
+	for _, conf := range Builders {
+		osArchSeen[conf.GOOS()+"-"+conf.GOARCH()] = true
+	}
+	for osArch := range osArchSeen {
+		if _, ok := SlowBotAliases[osArch]; ok {
+			continue
+		}
+		goos := strings.SplitN(osArch, "-", 2)[0]
+		if _, ok := SlowBotAliases[goos]; ok {
+			continue
+		}
+		t.Errorf("no SlowBotAliases entry covers GOOS/GOARCH combination %q", osArch)
+	}
+}
+
+func TestBuilderForSlowBot(t *testing.T) {
+	if _, ok := BuilderForSlowBot("not-a-real-alias"); ok {
+		t.Error("unknown alias unexpectedly resolved")
+	}
+	if _, ok := BuilderForSlowBot("ios-amd64"); ok {
+		t.Error("documented-missing alias unexpectedly resolved")
+	}
+	if _, ok := BuilderForSlowBot("freebsd"); !ok {
+		t.Error("expected \"freebsd\" alias to resolve to a builder")
+	}
+}
+
 func TestHostConfigsAllUsed(t *testing.T) {
 	used := map[string]bool{}
 	for _, conf := range Builders {
@@ -0,0 +1,87 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/build/types"
+)
+
+// atLeastGo1 reports whether goBranch is "master" or a
+// "release-branch.go1.N" branch with N >= n.
+//
+// It's the low-level helper atLeastGoVersion is built on; call sites
+// gating on a types.MajorMinor-valued field like
+// BuildConfig.MinimumGoVersion should use atLeastGoVersion instead.
+func atLeastGo1(goBranch string, n int) bool {
+	if goBranch == "master" {
+		// The development branch is always newer than any
+		// released minor version.
+		return true
+	}
+	const prefix = "release-branch.go1."
+	if !strings.HasPrefix(goBranch, prefix) {
+		// Not a release branch we understand; don't guess.
+		return false
+	}
+	minor, err := strconv.Atoi(goBranch[len(prefix):])
+	if err != nil {
+		return false
+	}
+	return minor >= n
+}
+
+// atLeastGoVersion reports whether goBranch is "master" or a
+// "release-branch.go1.N" branch at least as new as min.
+//
+// It compares a branch against a types.MajorMinor value such as
+// BuildConfig.MinimumGoVersion, in place of a hand-written branch
+// comparison like
+//
+//	goBranch == "release-branch.go1.12"
+//
+// Go's major version has been 1 for the lifetime of this package, so
+// min.Major isn't consulted.
+//
+// Its only caller in this checkout is HostSupportsGoBranch, and
+// nothing here calls goCommitContainsDeps. BuildsRepoPostSubmit and
+// BuildsRepoTryBot live on BuildConfig in builders.go, which isn't
+// part of this checkout, so nothing here applies the
+// MinimumGoVersion/MaximumGoVersion/GoDeps checks in those methods.
+func atLeastGoVersion(goBranch string, min types.MajorMinor) bool {
+	return atLeastGo1(goBranch, min.Minor)
+}
+
+// majorMinorAfter reports whether a is a strictly newer release than
+// b. It's used to combine a base builder's MinimumGoVersion with a
+// variant's, keeping whichever is newer.
+func majorMinorAfter(a, b types.MajorMinor) bool {
+	return a.Major > b.Major || (a.Major == b.Major && a.Minor > b.Minor)
+}
+
+// goCommitContainsDeps reports whether goRev, a Go commit hash, has
+// every commit hash in deps as an ancestor (or is itself one of them).
+//
+// It lets a BuildConfig declare GoDeps: []string{"<sha>"} for a
+// variant or platform that only works once a particular CL has
+// landed in the Go toolchain, without the builder's buildsRepo
+// closure needing to special-case the commit itself.
+//
+// hasAncestor is typically maintner.Corpus.GitCommit(goRev).HasAncestor,
+// wired in by the caller; it's a parameter here so this package
+// doesn't need to import maintner.
+func goCommitContainsDeps(goRev string, deps []string, hasAncestor func(commit, ancestor string) bool) bool {
+	for _, dep := range deps {
+		if goRev == dep {
+			continue
+		}
+		if !hasAncestor(goRev, dep) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import "strings"
+
+// iosMinimumGoVersion is the minor version N of the oldest Go 1.N
+// release that understands GOOS=ios as distinct from GOOS=darwin. It
+// is a plain minor number, not a types.MajorMinor; the matching gate
+// is MinimumGoVersion: types.MajorMinor{Major: 1, Minor: iosMinimumGoVersion}.
+const iosMinimumGoVersion = 14
+
+// iosGOOS reports the GOOS a builder named name should report, for
+// builders whose name starts with "ios-" (e.g. "ios-arm64-corellium").
+// It returns ok == false for any other builder name, leaving the
+// caller to fall back to its normal "parse the prefix of Name" logic.
+//
+// BuildConfig.GOOS() (in builders.go) should consult this before its
+// generic GOOS/GOARCH-from-Name parsing, so that "ios-arm64-corellium"
+// reports GOOS=ios rather than being parsed as darwin/arm64; today it
+// isn't wired in because builders.go isn't part of this checkout.
+func iosGOOS(name string) (goos string, ok bool) {
+	if !strings.HasPrefix(name, "ios-") {
+		return "", false
+	}
+	return "ios", true
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"time"
+
+	"golang.org/x/build/buildgo"
+)
+
+// SchedulerPolicy holds the per-builder scheduling knobs that used to
+// live in the coordinator's now-removed useScheduler code path. It
+// lets an individual BuildConfig tune how aggressively the
+// coordinator schedules work against it, without the coordinator
+// needing to hardcode builder names or types.
+type SchedulerPolicy struct {
+	// MaxAtOnce caps the number of simultaneous builds the
+	// coordinator will run for this builder. Zero means unlimited.
+	// Builders backed by scarce or expensive resources (long test
+	// shards, Android emulators, Corellium devices) should set this.
+	MaxAtOnce int
+
+	// Priority biases the order work is drained in: higher values
+	// are scheduled before lower ones. It's set per builder and
+	// defaults to zero; SchedulingCost doesn't distinguish trybot
+	// work from post-submit work, so trybots get no higher priority
+	// than post-submit builds unless a builder's Priority says so.
+	Priority int
+
+	// PreferNewer, if true, tells the scheduler to prefer the newest
+	// queued revision for this builder over older ones, rather than
+	// draining strictly in arrival order.
+	PreferNewer bool
+}
+
+// SchedulingCost returns a score for scheduling rev against c at now,
+// suitable for sorting a work queue: lower scores are drained first.
+// The coordinator doesn't need to know why one builder's queue entry
+// outranks another's, only that this number is comparable across
+// entries for the same builder.
+//
+// The cost starts from -Priority (so higher Priority sorts first),
+// and is adjusted by the age of the revision: older revisions reduce
+// the cost so they don't starve behind a stream of newer ones, unless
+// PreferNewer is set, in which case newer revisions reduce the cost
+// instead.
+//
+// Nothing calls SchedulingCost yet: the coordinator's work queue is
+// in cmd/coordinator, which isn't part of this checkout. Restoring
+// actual scheduling means having that queue sort by this instead of
+// the hardcoded per-builder-type cases useScheduler used to have;
+// wire it in once cmd/coordinator is checked out here.
+func (c *BuildConfig) SchedulingCost(rev buildgo.BuilderRev, now time.Time) float64 {
+	age := now.Sub(rev.Time()).Seconds()
+	if c.SchedulerPolicy.PreferNewer {
+		age = -age
+	}
+	// Normalize age into hours so it nudges, rather than dominates,
+	// a large Priority spread.
+	return float64(-c.SchedulerPolicy.Priority) - age/3600
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import "strings"
+
+// SlowBotAliases maps short names that can be used in a Gerrit
+// "TRY=" comment to the name of the builder in Builders that the
+// alias resolves to.
+//
+// An alias may be a bare GOOS ("freebsd"), a bare GOARCH ("arm64"),
+// a GOOS-GOARCH pair ("windows-386"), or a curated shortcut for a
+// builder that doesn't follow the GOOS-GOARCH naming scheme
+// ("android", "wasm", "darwin-arm64").
+//
+// A small number of aliases are intentionally mapped to the empty
+// string. Those document combinations that users are likely to type
+// but that we don't currently run a builder for; BuilderForSlowBot
+// reports ok == false for them, same as for an alias that isn't in
+// the map at all.
+var SlowBotAliases = map[string]string{
+	"386":      "linux-386",
+	"amd64":    "linux-amd64",
+	"arm":      "linux-arm",
+	"arm64":    "linux-arm64",
+	"ppc64":    "linux-ppc64",
+	"ppc64le":  "linux-ppc64le",
+	"mips":     "linux-mips",
+	"mips64":   "linux-mips64",
+	"mips64le": "linux-mips64le",
+	"mipsle":   "linux-mipsle",
+	"s390x":    "linux-s390x",
+
+	"android":       "android-amd64-emu",
+	"android-386":   "android-386-emu",
+	"android-amd64": "android-amd64-emu",
+	"android-arm":   "android-arm-corellium",
+	"android-arm64": "android-arm64-corellium",
+
+	"darwin":       "darwin-amd64-10_14",
+	"darwin-amd64": "darwin-amd64-10_14",
+	"darwin-arm64": "darwin-arm64-corellium",
+	"darwin-386":   "darwin-386-10_14",
+
+	"freebsd":       "freebsd-amd64-12_0",
+	"freebsd-386":   "freebsd-386-12_0",
+	"freebsd-amd64": "freebsd-amd64-12_0",
+
+	"ios":       "ios-arm64-corellium",
+	"ios-arm64": "ios-arm64-corellium",
+	// ios/amd64 (the simulator) isn't built by any builder yet.
+	"ios-amd64": "",
+
+	"js":      "js-wasm",
+	"wasm":    "js-wasm",
+	"js-wasm": "js-wasm",
+
+	"linux":       "linux-amd64",
+	"linux-386":   "linux-386",
+	"linux-amd64": "linux-amd64",
+
+	"nacl":          "nacl-amd64p32",
+	"nacl-amd64p32": "nacl-amd64p32",
+
+	"netbsd":       "netbsd-amd64-8_0",
+	"netbsd-amd64": "netbsd-amd64-8_0",
+	"netbsd-386":   "netbsd-386-8_0",
+
+	"openbsd":       "openbsd-amd64-64",
+	"openbsd-386":   "openbsd-386-64",
+	"openbsd-amd64": "openbsd-amd64-64",
+
+	"plan9":     "plan9-386",
+	"plan9-386": "plan9-386",
+	"plan9-arm": "plan9-arm",
+
+	"windows":       "windows-amd64-2016",
+	"windows-386":   "windows-386-2008",
+	"windows-amd64": "windows-amd64-2016",
+
+	"race": "linux-amd64-race",
+
+	"aix":       "aix-ppc64",
+	"aix-ppc64": "aix-ppc64",
+	"illumos":   "illumos-amd64-joyent",
+	"solaris":   "solaris-amd64-oraclerel",
+}
+
+// BuilderForSlowBot resolves alias, a short name as typed in a Gerrit
+// "TRY=" comment, to the concrete BuildConfig it refers to.
+//
+// It reports ok == false if alias isn't a known alias, or if it's a
+// documented gap in SlowBotAliases (an alias mapped to the empty
+// string) or doesn't resolve to a builder currently in Builders.
+//
+// The coordinator's TRY= comment parser (cmd/coordinator) should call
+// this instead of hand-rolling its own alias table; today it isn't
+// wired in because cmd/coordinator isn't part of this checkout.
+func BuilderForSlowBot(alias string) (*BuildConfig, bool) {
+	alias = strings.TrimSpace(alias)
+	name, ok := SlowBotAliases[alias]
+	if !ok || name == "" {
+		return nil, false
+	}
+	bc, ok := Builders[name]
+	if !ok {
+		return nil, false
+	}
+	return bc, true
+}
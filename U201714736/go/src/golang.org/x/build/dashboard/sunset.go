@@ -0,0 +1,75 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import "golang.org/x/build/types"
+
+// OSRelease describes the range of Go release branches a particular
+// OS release is supported on, e.g. "FreeBSD 11.1 was dropped after
+// Go 1.12" or "Windows 2008 has no upper bound (still supported)".
+//
+// This exists so that sunsetting an OS release is a one-line edit to
+// osSunsets instead of a hunt through every buildsRepo closure that
+// happens to reference it (see the FreeBSD 10.x/11.1, FreeBSD 11.2,
+// and macOS 10.10 sunsets, each of which touched several closures).
+type OSRelease struct {
+	GOOS    string
+	Release string // e.g. "11.1", "10.10", "2008"
+
+	// FirstGoVersion is the oldest Go release branch this OS release
+	// is supported on. The zero value means no lower bound (supported
+	// since the dawn of the builder).
+	FirstGoVersion types.MajorMinor
+
+	// LastGoVersion is the newest Go release branch this OS release
+	// is supported on. The zero value means no upper bound (still
+	// supported on master and all release branches).
+	LastGoVersion types.MajorMinor
+}
+
+// osSunsets is keyed by HostType and records the support window for
+// the OS release that host type builds. Host types not present here
+// have no declared sunset and are assumed to support every branch;
+// buildsRepoAtAll would fall back to its existing behavior for them.
+var osSunsets = map[string]OSRelease{
+	"host-freebsd-10_3": {GOOS: "freebsd", Release: "10.3", LastGoVersion: types.MajorMinor{Major: 1, Minor: 12}},
+	"host-freebsd-11_1": {GOOS: "freebsd", Release: "11.1", LastGoVersion: types.MajorMinor{Major: 1, Minor: 12}},
+	"host-freebsd-11_2": {GOOS: "freebsd", Release: "11.2", LastGoVersion: types.MajorMinor{Major: 1, Minor: 16}},
+	"host-darwin-10_10": {GOOS: "darwin", Release: "10.10", LastGoVersion: types.MajorMinor{Major: 1, Minor: 12}},
+	"host-windows-2008": {GOOS: "windows", Release: "2008"}, // no upper bound
+}
+
+// HostSupportsGoBranch reports whether hostType, per osSunsets, is
+// still a supported target for goBranch. Host types with no entry in
+// osSunsets are always supported.
+//
+// buildsRepoAtAll's hand-written FreeBSD/macOS/Windows/NetBSD version
+// checks should be replaced with a call to this, ahead of (and in
+// place of) those checks, so a sunset is a one-line osSunsets edit
+// instead of a hunt through buildsRepoAtAll. That hasn't happened
+// here: buildsRepoAtAll lives on BuildConfig in builders.go, which
+// isn't part of this checkout. TestBuilderConfig's hand-maintained
+// FreeBSD 10.x/11.1 cases (search for "go1.12.html") are the ones
+// that dispatch should eventually make redundant. TestHostSupportsGoBranch
+// exercises HostSupportsGoBranch directly; the cases generated from
+// osSunsets and appended to TestBuilderConfig check the same windows
+// against BuildsRepoPostSubmit.
+func HostSupportsGoBranch(hostType, goBranch string) bool {
+	rel, ok := osSunsets[hostType]
+	if !ok {
+		return true
+	}
+	zero := types.MajorMinor{}
+	if rel.FirstGoVersion != zero && !atLeastGoVersion(goBranch, rel.FirstGoVersion) {
+		return false
+	}
+	if rel.LastGoVersion != zero {
+		afterLast := types.MajorMinor{Major: rel.LastGoVersion.Major, Minor: rel.LastGoVersion.Minor + 1}
+		if atLeastGoVersion(goBranch, afterLast) {
+			return false
+		}
+	}
+	return true
+}
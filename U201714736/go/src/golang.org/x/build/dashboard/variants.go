@@ -0,0 +1,79 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"fmt"
+
+	"golang.org/x/build/types"
+)
+
+// BuildVariant describes a builder that's derived from an existing
+// base builder by tweaking its build environment, rather than by
+// declaring a whole new BuildConfig from scratch. It's meant for
+// things like GOEXPERIMENT=regabi or GO386=softfloat: a handful of
+// extra env vars plus some extra gating, on top of a builder that
+// already exists.
+type BuildVariant struct {
+	// Name suffixes the base builder's name to form the variant's
+	// name, e.g. base "linux-386" with Name "softfloat" becomes
+	// "linux-386-softfloat".
+	Name string
+
+	// Env lists additional environment variables to append to the
+	// base builder's environment, such as "GO386=softfloat" or
+	// "GOEXPERIMENT=regabi".
+	Env []string
+
+	// MinimumGoVersion, if non-zero, restricts the variant to Go
+	// branches at least as new as the given release. See
+	// atLeastGoVersion.
+	MinimumGoVersion types.MajorMinor
+
+	// GoDeps lists Go commit hashes that must be ancestors of the
+	// tested Go commit for the variant to apply, e.g. the CL that
+	// introduced the GOEXPERIMENT in the first place.
+	GoDeps []string
+
+	// Repos restricts the variant to the given repos. A nil slice
+	// means the variant builds the same repos as its base builder.
+	Repos []string
+}
+
+// RegisterVariant clones the BuildConfig registered under base,
+// applies v's env and gating on top of it, and registers the result
+// in Builders under "<base>-<v.Name>".
+//
+// It panics if base isn't already registered, or if a builder is
+// already registered under the variant's computed name; both are
+// programmer errors caught at init time, same as duplicate entries
+// in a Builders map literal would be.
+func RegisterVariant(base string, v BuildVariant) *BuildConfig {
+	bc, ok := Builders[base]
+	if !ok {
+		panic(fmt.Sprintf("dashboard: RegisterVariant: unknown base builder %q", base))
+	}
+	name := base + "-" + v.Name
+	if _, exists := Builders[name]; exists {
+		panic(fmt.Sprintf("dashboard: RegisterVariant: builder %q already registered", name))
+	}
+
+	nc := *bc // shallow copy; variants don't mutate the base's slices in place
+	nc.Name = name
+	nc.env = append(append([]string{}, bc.env...), v.Env...)
+	if majorMinorAfter(v.MinimumGoVersion, nc.MinimumGoVersion) {
+		nc.MinimumGoVersion = v.MinimumGoVersion
+	}
+	nc.GoDeps = append(append([]string{}, bc.GoDeps...), v.GoDeps...)
+	if v.Repos != nil {
+		nc.Repos = append([]string{}, v.Repos...)
+	}
+
+	if Builders == nil {
+		Builders = map[string]*BuildConfig{}
+	}
+	Builders[name] = &nc
+	return &nc
+}
@@ -0,0 +1,207 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintapi
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+)
+
+// fakeRefLister is a nonChangeRefLister backed by an in-memory map of
+// ref name to hash, for testing logic that only needs ref names and
+// iteration, not a real maintner.Corpus.
+type fakeRefLister map[string]maintner.GitHash
+
+func (f fakeRefLister) ForeachNonChangeRef(fn func(ref string, hash maintner.GitHash) error) error {
+	refs := make([]string, 0, len(f))
+	for ref := range f {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		if err := fn(ref, f[ref]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestNextVersion(t *testing.T) {
+	goProj := fakeRefLister{
+		"refs/tags/go1.20":                 {},
+		"refs/tags/go1.21":                 {},
+		"refs/tags/go1.21.5":               {},
+		"refs/tags/go1.22beta1":            {},
+		"refs/heads/release-branch.go1.22": {},
+	}
+	tests := []struct {
+		kind apipb.ReleaseKind
+		want string
+	}{
+		// CurrentMinor seeds off go1.22, the in-development release,
+		// because go1.22beta1 exists: see the doc comment on
+		// nextVersion's ForeachNonChangeRef callback for why that's
+		// intentional rather than a bug.
+		{apipb.ReleaseKind_CurrentMinor, "go1.22.1"},
+		{apipb.ReleaseKind_PrevMinor, "go1.21.1"},
+		{apipb.ReleaseKind_Beta, "go1.22beta2"}, // go1.22beta1 already used
+		{apipb.ReleaseKind_RC, "go1.22rc1"},
+		{apipb.ReleaseKind_Major, "go1.22"}, // go1.22 itself isn't tagged yet
+	}
+	for _, tt := range tests {
+		got, err := nextVersion(goProj, tt.kind)
+		if err != nil {
+			t.Errorf("nextVersion(%v) error: %v", tt.kind, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("nextVersion(%v) = %q; want %q", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestNextVersionNoTags(t *testing.T) {
+	if _, err := nextVersion(fakeRefLister{}, apipb.ReleaseKind_CurrentMinor); err == nil {
+		t.Error("expected an error with no go1.N tags present")
+	}
+}
+
+func TestNextVersionUnknownKind(t *testing.T) {
+	goProj := fakeRefLister{"refs/tags/go1.21": {}}
+	if _, err := nextVersion(goProj, apipb.ReleaseKind(99)); err == nil {
+		t.Error("expected an error for an unknown ReleaseKind")
+	}
+}
+
+func TestOutstandingPrereleases(t *testing.T) {
+	goProj := fakeRefLister{
+		"refs/tags/go1.21":                 {},
+		"refs/tags/go1.22beta1":            {},
+		"refs/tags/go1.22beta2":            {},
+		"refs/tags/go1.22rc1":              {},
+		"refs/heads/release-branch.go1.22": {},
+	}
+	rs, err := outstandingPrereleases(goProj)
+	if err != nil {
+		t.Fatalf("outstandingPrereleases: %v", err)
+	}
+	var got []string
+	for _, r := range rs {
+		got = append(got, r.TagName)
+	}
+	want := []string{"go1.22rc1", "go1.22beta2", "go1.22beta1"}
+	if !equalStrings(got, want) {
+		t.Errorf("outstandingPrereleases tags = %v; want %v", got, want)
+	}
+}
+
+func TestOutstandingPrereleasesNoneInDevelopment(t *testing.T) {
+	// go1.22 is finalized (has both a tag and a branch), so there's
+	// no in-development release to report prereleases for, even
+	// though an earlier beta tag still exists in history.
+	goProj := fakeRefLister{
+		"refs/tags/go1.22":                 {},
+		"refs/tags/go1.22beta1":            {},
+		"refs/heads/release-branch.go1.22": {},
+	}
+	rs, err := outstandingPrereleases(goProj)
+	if err != nil {
+		t.Fatalf("outstandingPrereleases: %v", err)
+	}
+	if len(rs) != 0 {
+		t.Errorf("got %d outstanding prereleases; want 0", len(rs))
+	}
+}
+
+func TestPageTryWork(t *testing.T) {
+	now := time.Now().Unix()
+	full := &apipb.GoFindTryWorkResponse{
+		Waiting: []*apipb.GerritTryWorkItem{
+			{Commit: "old", TrybotRequestedTime: now - 1000},
+			{Commit: "mid", TrybotRequestedTime: now - 500},
+			{Commit: "new", TrybotRequestedTime: now - 100},
+		},
+	}
+
+	// No MinAge/Limit: returns the same response, unmodified.
+	if got := pageTryWork(full, &apipb.GoFindTryWorkRequest{}); got != full {
+		t.Errorf("with no MinAge/Limit, pageTryWork should return res unchanged; got a different value")
+	}
+
+	// Limit caps the number of items, in whatever order res.Waiting
+	// is already in (the caller is expected to have sorted it).
+	got := pageTryWork(full, &apipb.GoFindTryWorkRequest{Limit: 2})
+	if len(got.Waiting) != 2 || got.Waiting[0].Commit != "old" || got.Waiting[1].Commit != "mid" {
+		t.Errorf("Limit: 2 = %v; want [old mid]", commits(got.Waiting))
+	}
+
+	// MinAge filters out items requested too recently: only "old",
+	// at ~1000s, clears a 600s minimum age; "mid" (~500s) and "new"
+	// (~100s) don't.
+	got = pageTryWork(full, &apipb.GoFindTryWorkRequest{MinAge: 600})
+	if len(got.Waiting) != 1 || got.Waiting[0].Commit != "old" {
+		t.Errorf("MinAge: 600 = %v; want [old]", commits(got.Waiting))
+	}
+
+	// Original response is never mutated by paging.
+	if len(full.Waiting) != 3 {
+		t.Errorf("pageTryWork mutated the input response's Waiting slice")
+	}
+}
+
+func TestParseGoModXRepoRequires(t *testing.T) {
+	const goMod = `module golang.org/x/build
+
+go 1.21
+
+require (
+	golang.org/x/crypto v0.17.0
+	golang.org/x/net v0.19.0 // indirect
+	rsc.io/quote v1.5.2
+)
+
+require golang.org/x/sys v0.15.0
+
+require golang.org/x/nonexistent v1.0.0
+`
+	got := parseGoModXRepoRequires([]byte(goMod))
+	want := []xRepoRequire{
+		{Project: "crypto", Version: "v0.17.0"},
+		{Project: "net", Version: "v0.19.0"},
+		{Project: "sys", Version: "v0.15.0"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseGoModXRepoRequires = %+v; want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func commits(items []*apipb.GerritTryWorkItem) []string {
+	var s []string
+	for _, it := range items {
+		s = append(s, it.Commit)
+	}
+	return s
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
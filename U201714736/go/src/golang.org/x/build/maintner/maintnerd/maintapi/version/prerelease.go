@@ -0,0 +1,39 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package version
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// tagRE matches goX, goX.Y, goX.Y.Z, and their "beta"/"rc" prerelease
+// variants, e.g. "go1", "go1.23", "go1.2.3", "go1.23beta2", "go1.23rc1".
+var tagRE = regexp.MustCompile(`^go(\d+)(?:\.(\d+)(?:\.(\d+))?)?(?:beta\d+|rc\d+)?$`)
+
+// ParseTagAllowPrerelease parses the major and minor version out of a
+// go tag name, the same as ParseTag, except it also accepts the
+// "beta" and "rc" prerelease suffixes that ParseTag rejects (e.g.
+// "go1.23beta2", "go1.23rc1" parse as major 1, minor 23).
+//
+// It reports ok == false for tag names that don't match the goX[.Y[.Z]]
+// pattern at all, with or without a prerelease suffix.
+func ParseTagAllowPrerelease(tagName string) (major, minor int, ok bool) {
+	m := tagRE.FindStringSubmatch(tagName)
+	if m == nil {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	if m[2] != "" {
+		minor, err = strconv.Atoi(m[2])
+		if err != nil {
+			return 0, 0, false
+		}
+	}
+	return major, minor, true
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package version
+
+import "testing"
+
+func TestParseTagAllowPrerelease(t *testing.T) {
+	tests := []struct {
+		tag       string
+		wantMajor int
+		wantMinor int
+		wantOK    bool
+	}{
+		{"go1", 1, 0, true},
+		{"go1.2", 1, 2, true},
+		{"go1.2.3", 1, 2, true},
+		{"go1.23beta2", 1, 23, true},
+		{"go1.23rc1", 1, 23, true},
+		{"go1beta1", 1, 0, true},
+		{"not-a-tag", 0, 0, false},
+		{"go1.2.3.4", 0, 0, false},
+		{"go1.23gamma1", 0, 0, false},
+	}
+	for _, tt := range tests {
+		major, minor, ok := ParseTagAllowPrerelease(tt.tag)
+		if major != tt.wantMajor || minor != tt.wantMinor || ok != tt.wantOK {
+			t.Errorf("ParseTagAllowPrerelease(%q) = %d, %d, %v; want %d, %d, %v",
+				tt.tag, major, minor, ok, tt.wantMajor, tt.wantMinor, tt.wantOK)
+		}
+	}
+}